@@ -14,6 +14,11 @@ type BitArray struct {
 	lenpad int
 	length int
 	bytes  []byte
+
+	// ranks caches cumulative popcounts for Rank1/Select1, see BuildIndex.
+	// It is nil until BuildIndex is called, and is invalidated back to nil
+	// by any method that mutates bytes.
+	ranks []uint32
 }
 
 const (
@@ -103,6 +108,7 @@ func (bits *BitArray) Put(n int, bit int) (int, error) {
 		return 0, err
 	}
 	prev, _ := bits.Get(n)
+	bits.ranks = nil
 
 	if bit == 1 {
 		bits.bytes[n/8] |= 1 << byte(n%8)
@@ -127,6 +133,7 @@ func (bits *BitArray) Set(low int, high int) error {
 		}
 	}
 
+	bits.ranks = nil
 	lb, hb := low/8, high/8
 
 	if lb < hb {
@@ -156,6 +163,7 @@ func (bits *BitArray) Clear(low int, high int) error {
 		}
 	}
 
+	bits.ranks = nil
 	lb, hb := low/8, high/8
 
 	if lb < hb {
@@ -185,6 +193,7 @@ func (bits *BitArray) Not(low int, high int) error {
 		}
 	}
 
+	bits.ranks = nil
 	lb, hb := low/8, high/8
 
 	if lb < hb {
@@ -255,6 +264,96 @@ func (bits *BitArray) Lt(obits *BitArray) bool {
 	return true
 }
 
+func (bits *BitArray) lengthMismatch(obits *BitArray) error {
+	if bits.length != obits.length {
+		msg := fmt.Sprintf("length mismatch: %d != %d", bits.length, obits.length)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func word2bytes(w uint64) []byte {
+	buf := new(bytes.Buffer)
+	if err := binary.Write(buf, binary.BigEndian, w); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+// And returns a new BitArray holding the bitwise AND of bits and obits.
+// If lengths aren't same, return error.
+func (bits *BitArray) And(obits *BitArray) (*BitArray, error) {
+	out := New(bits.length)
+	err := bits.combine(obits, out, func(a, b uint64) uint64 { return a & b })
+	return out, err
+}
+
+// Or returns a new BitArray holding the bitwise OR of bits and obits.
+// If lengths aren't same, return error.
+func (bits *BitArray) Or(obits *BitArray) (*BitArray, error) {
+	out := New(bits.length)
+	err := bits.combine(obits, out, func(a, b uint64) uint64 { return a | b })
+	return out, err
+}
+
+// Xor returns a new BitArray holding the bitwise XOR of bits and obits.
+// If lengths aren't same, return error.
+func (bits *BitArray) Xor(obits *BitArray) (*BitArray, error) {
+	out := New(bits.length)
+	err := bits.combine(obits, out, func(a, b uint64) uint64 { return a ^ b })
+	return out, err
+}
+
+// AndNot returns a new BitArray holding bits with every bit set in obits
+// cleared (bits AND NOT obits). If lengths aren't same, return error.
+func (bits *BitArray) AndNot(obits *BitArray) (*BitArray, error) {
+	out := New(bits.length)
+	err := bits.combine(obits, out, func(a, b uint64) uint64 { return a &^ b })
+	return out, err
+}
+
+// AndInPlace replaces bits with the bitwise AND of bits and obits.
+// If lengths aren't same, return error.
+func (bits *BitArray) AndInPlace(obits *BitArray) error {
+	return bits.combine(obits, bits, func(a, b uint64) uint64 { return a & b })
+}
+
+// OrInPlace replaces bits with the bitwise OR of bits and obits.
+// If lengths aren't same, return error.
+func (bits *BitArray) OrInPlace(obits *BitArray) error {
+	return bits.combine(obits, bits, func(a, b uint64) uint64 { return a | b })
+}
+
+// XorInPlace replaces bits with the bitwise XOR of bits and obits.
+// If lengths aren't same, return error.
+func (bits *BitArray) XorInPlace(obits *BitArray) error {
+	return bits.combine(obits, bits, func(a, b uint64) uint64 { return a ^ b })
+}
+
+// AndNotInPlace replaces bits with bits having every bit set in obits
+// cleared. If lengths aren't same, return error.
+func (bits *BitArray) AndNotInPlace(obits *BitArray) error {
+	return bits.combine(obits, bits, func(a, b uint64) uint64 { return a &^ b })
+}
+
+// combine writes op(bits[i], obits[i]) word-by-word into out, which may
+// alias bits for in-place operators. If lengths aren't same, return error.
+func (bits *BitArray) combine(obits *BitArray, out *BitArray, op func(a, b uint64) uint64) error {
+	if err := bits.lengthMismatch(obits); err != nil {
+		return err
+	}
+	if err := bits.lengthMismatch(out); err != nil {
+		return err
+	}
+	out.ranks = nil
+	for i := 0; i < bits.lenpad; i += _BytesPW {
+		wself := bytes2word(bits.bytes[i : i+_BytesPW])
+		wother := bytes2word(obits.bytes[i : i+_BytesPW])
+		copy(out.bytes[i:i+_BytesPW], word2bytes(op(wself, wother)))
+	}
+	return nil
+}
+
 // Convert the BitArray to a array of integers, and return.
 func (bits *BitArray) ToArray() []int {
 	ints := make([]int, bits.length, bits.length)
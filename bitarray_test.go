@@ -226,3 +226,108 @@ func TestLeq(t *testing.T) {
 
 	_testLtOrEq(t, true)
 }
+
+func TestBitwiseOps(t *testing.T) {
+	fmt.Println("Test: And/Or/Xor/AndNot")
+
+	n := 128
+	a := New(n)
+	b := New(n)
+	a.Set(0, 63)
+	b.Set(32, 95)
+
+	and, err := a.And(b)
+	if err != nil {
+		t.Fatalf("And failed: %v\n", err)
+	}
+	want := New(n)
+	want.Set(32, 63)
+	if !and.Eq(want) {
+		t.Fatalf("expect And result %v, got %v\n", want.ToArray(), and.ToArray())
+	}
+
+	or, err := a.Or(b)
+	if err != nil {
+		t.Fatalf("Or failed: %v\n", err)
+	}
+	want = New(n)
+	want.Set(0, 95)
+	if !or.Eq(want) {
+		t.Fatalf("expect Or result %v, got %v\n", want.ToArray(), or.ToArray())
+	}
+
+	xor, err := a.Xor(b)
+	if err != nil {
+		t.Fatalf("Xor failed: %v\n", err)
+	}
+	want = New(n)
+	want.Set(0, 31)
+	want.Set(64, 95)
+	if !xor.Eq(want) {
+		t.Fatalf("expect Xor result %v, got %v\n", want.ToArray(), xor.ToArray())
+	}
+
+	andNot, err := a.AndNot(b)
+	if err != nil {
+		t.Fatalf("AndNot failed: %v\n", err)
+	}
+	want = New(n)
+	want.Set(0, 31)
+	if !andNot.Eq(want) {
+		t.Fatalf("expect AndNot result %v, got %v\n", want.ToArray(), andNot.ToArray())
+	}
+
+	if _, err := a.And(New(n + 1)); err == nil {
+		t.Fatalf("expect error for length mismatch\n")
+	}
+}
+
+func TestBitwiseOpsInPlace(t *testing.T) {
+	fmt.Println("Test: AndInPlace/OrInPlace/XorInPlace/AndNotInPlace")
+
+	n := 128
+	a := New(n)
+	b := New(n)
+	a.Set(0, 63)
+	b.Set(32, 95)
+
+	got := New(n)
+	got.Set(0, 63)
+	if err := got.AndInPlace(b); err != nil {
+		t.Fatalf("AndInPlace failed: %v\n", err)
+	}
+	want, _ := a.And(b)
+	if !got.Eq(want) {
+		t.Fatalf("expect AndInPlace result %v, got %v\n", want.ToArray(), got.ToArray())
+	}
+
+	got = New(n)
+	got.Set(0, 63)
+	if err := got.OrInPlace(b); err != nil {
+		t.Fatalf("OrInPlace failed: %v\n", err)
+	}
+	want, _ = a.Or(b)
+	if !got.Eq(want) {
+		t.Fatalf("expect OrInPlace result %v, got %v\n", want.ToArray(), got.ToArray())
+	}
+
+	got = New(n)
+	got.Set(0, 63)
+	if err := got.XorInPlace(b); err != nil {
+		t.Fatalf("XorInPlace failed: %v\n", err)
+	}
+	want, _ = a.Xor(b)
+	if !got.Eq(want) {
+		t.Fatalf("expect XorInPlace result %v, got %v\n", want.ToArray(), got.ToArray())
+	}
+
+	got = New(n)
+	got.Set(0, 63)
+	if err := got.AndNotInPlace(b); err != nil {
+		t.Fatalf("AndNotInPlace failed: %v\n", err)
+	}
+	want, _ = a.AndNot(b)
+	if !got.Eq(want) {
+		t.Fatalf("expect AndNotInPlace result %v, got %v\n", want.ToArray(), got.ToArray())
+	}
+}
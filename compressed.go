@@ -0,0 +1,202 @@
+package bitarray
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Chunk encodings used by MarshalCompressed/UnmarshalCompressed.
+const (
+	chunkSparseSet   byte = 0 // sorted uint16 array of set-bit offsets
+	chunkSparseClear byte = 1 // sorted uint16 array of clear-bit offsets
+	chunkRaw         byte = 2 // raw bitmap, compressedChunkBytes long
+)
+
+const (
+	compressedChunkBits  = 65536
+	compressedChunkBytes = compressedChunkBits / 8 // 8192
+)
+
+// MarshalCompressed encodes the BitArray into 64K-bit chunks, choosing per
+// chunk one of three encodings by its popcount: a sorted uint16 array of
+// set-bit offsets when the chunk is sparse (popcount < 4096), a sorted
+// uint16 array of clear-bit offsets when the chunk is dense (popcount >
+// 61440), or a raw bitmap otherwise. The same thresholds apply to a shorter
+// final chunk, so its encoding is not always the smallest. The result round-trips
+// exactly through UnmarshalCompressed and Eq.
+func (bits *BitArray) MarshalCompressed() ([]byte, error) {
+	nchunks := (bits.length + compressedChunkBits - 1) / compressedChunkBits
+
+	type chunk struct {
+		kind    byte
+		card    int
+		payload []byte
+	}
+	chunks := make([]chunk, 0, nchunks)
+
+	for c := 0; c < nchunks; c++ {
+		lo := c * compressedChunkBits
+		hi := lo + compressedChunkBits
+		if hi > bits.length {
+			hi = bits.length
+		}
+
+		// compressedChunkBits is word-aligned, so lo is always a word
+		// boundary; only the final chunk's hi may fall mid-word, and the
+		// padding bits beyond bits.length are always 0 (see the lenpad
+		// invariant), so summing whole-word popcounts is exact.
+		card := 0
+		for w := lo / BitsPW; w < (hi+BitsPW-1)/BitsPW; w++ {
+			word := bytes2word(bits.bytes[w*_BytesPW : (w+1)*_BytesPW])
+			card += countbits64(word)
+		}
+
+		var kind byte
+		var payload []byte
+		switch {
+		case card < 4096:
+			kind = chunkSparseSet
+			for i, ok := bits.NextSet(lo); ok && i < hi; i, ok = bits.NextSet(i + 1) {
+				payload = appendUint16(payload, uint16(i-lo))
+			}
+		case card > 61440:
+			kind = chunkSparseClear
+			for i, ok := bits.NextClear(lo); ok && i < hi; i, ok = bits.NextClear(i + 1) {
+				payload = appendUint16(payload, uint16(i-lo))
+			}
+		default:
+			kind = chunkRaw
+			byteLo := lo / 8
+			byteHi := byteLo + compressedChunkBytes
+			if byteHi > bits.lenpad {
+				byteHi = bits.lenpad
+			}
+			payload = append(payload, bits.bytes[byteLo:byteHi]...)
+		}
+
+		chunks = append(chunks, chunk{kind, card, payload})
+	}
+
+	var buf bytes.Buffer
+	header := make([]byte, binary.MaxVarintLen64)
+
+	n := binary.PutUvarint(header, uint64(bits.length))
+	buf.Write(header[:n])
+	n = binary.PutUvarint(header, uint64(nchunks))
+	buf.Write(header[:n])
+
+	for _, ch := range chunks {
+		buf.WriteByte(ch.kind)
+		n = binary.PutUvarint(header, uint64(ch.card))
+		buf.Write(header[:n])
+		n = binary.PutUvarint(header, uint64(len(ch.payload)))
+		buf.Write(header[:n])
+	}
+	for _, ch := range chunks {
+		buf.Write(ch.payload)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalCompressed decodes a BitArray written by MarshalCompressed.
+func UnmarshalCompressed(data []byte) (*BitArray, error) {
+	br := bufio.NewReader(bytes.NewReader(data))
+
+	length, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	nchunks, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if want := uint64((int(length) + compressedChunkBits - 1) / compressedChunkBits); nchunks != want {
+		msg := fmt.Sprintf("stored chunk count %d does not match expected %d for length %d", nchunks, want, length)
+		return nil, errors.New(msg)
+	}
+
+	type descriptor struct {
+		kind byte
+		card int
+		size int
+	}
+	descs := make([]descriptor, nchunks)
+	for i := range descs {
+		kind, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		card, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		descs[i] = descriptor{kind, int(card), int(size)}
+	}
+
+	out := New(int(length))
+
+	for c, d := range descs {
+		lo := c * compressedChunkBits
+		hi := lo + compressedChunkBits
+		if hi > out.length {
+			hi = out.length
+		}
+
+		payload := make([]byte, d.size)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return nil, err
+		}
+
+		if (d.kind == chunkSparseSet || d.kind == chunkSparseClear) && len(payload)%2 != 0 {
+			msg := fmt.Sprintf("chunk %d: odd payload length %d for a uint16 offset array", c, len(payload))
+			return nil, errors.New(msg)
+		}
+
+		switch d.kind {
+		case chunkRaw:
+			byteLo := lo / 8
+			if byteLo+len(payload) > out.lenpad {
+				msg := fmt.Sprintf("chunk %d: raw payload of %d bytes overruns the BitArray at offset %d", c, len(payload), byteLo)
+				return nil, errors.New(msg)
+			}
+			copy(out.bytes[byteLo:byteLo+len(payload)], payload)
+		case chunkSparseSet:
+			for i := 0; i < len(payload); i += 2 {
+				off := int(binary.BigEndian.Uint16(payload[i : i+2]))
+				if _, err := out.Put(lo+off, 1); err != nil {
+					return nil, err
+				}
+			}
+		case chunkSparseClear:
+			if hi > lo {
+				if err := out.Set(lo, hi-1); err != nil {
+					return nil, err
+				}
+			}
+			for i := 0; i < len(payload); i += 2 {
+				off := int(binary.BigEndian.Uint16(payload[i : i+2]))
+				if _, err := out.Put(lo+off, 0); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			msg := fmt.Sprintf("unknown compressed chunk kind %d", d.kind)
+			return nil, errors.New(msg)
+		}
+	}
+
+	return out, nil
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
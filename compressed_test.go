@@ -0,0 +1,91 @@
+package bitarray
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testCompressedRoundTrip(t *testing.T, bits *BitArray) {
+	data, err := bits.MarshalCompressed()
+	if err != nil {
+		t.Fatalf("MarshalCompressed failed: %v\n", err)
+	}
+
+	got, err := UnmarshalCompressed(data)
+	if err != nil {
+		t.Fatalf("UnmarshalCompressed failed: %v\n", err)
+	}
+
+	if !bits.Eq(got) {
+		t.Fatalf("expect round-trip equal, got %v != %v\n", bits.ToArray(), got.ToArray())
+	}
+}
+
+func TestMarshalCompressedSparseSet(t *testing.T) {
+	fmt.Println("Test: MarshalCompressed sparse set-bit chunk")
+
+	bits := New(compressedChunkBits*2 + 17)
+	for i := 0; i < bits.Len(); i += 1000 {
+		bits.Put(i, 1)
+	}
+
+	testCompressedRoundTrip(t, bits)
+}
+
+func TestMarshalCompressedSparseClear(t *testing.T) {
+	fmt.Println("Test: MarshalCompressed sparse clear-bit chunk")
+
+	bits := New(compressedChunkBits*2 + 17)
+	bits.Set(0, bits.Len()-1)
+	for i := 0; i < bits.Len(); i += 1000 {
+		bits.Put(i, 0)
+	}
+
+	testCompressedRoundTrip(t, bits)
+}
+
+func TestMarshalCompressedRaw(t *testing.T) {
+	fmt.Println("Test: MarshalCompressed raw bitmap chunk")
+
+	bits := New(compressedChunkBits)
+	for i := 0; i < bits.Len(); i += 2 {
+		bits.Put(i, 1)
+	}
+
+	testCompressedRoundTrip(t, bits)
+}
+
+func TestMarshalCompressedEmpty(t *testing.T) {
+	fmt.Println("Test: MarshalCompressed empty BitArray")
+
+	testCompressedRoundTrip(t, New(0))
+	testCompressedRoundTrip(t, New(3))
+}
+
+func TestUnmarshalCompressedRejectsCorruptHeader(t *testing.T) {
+	fmt.Println("Test: UnmarshalCompressed rejects a corrupt chunk count")
+
+	// length=8 (1 chunk expected), but claims 5 chunks.
+	data := []byte{8, 5}
+	if _, err := UnmarshalCompressed(data); err == nil {
+		t.Fatalf("expect error for chunk count mismatch\n")
+	}
+
+	// A single chunkRaw descriptor whose payload overruns the BitArray.
+	var buf []byte
+	buf = append(buf, 8, 1)                 // length=8, nchunks=1
+	buf = append(buf, chunkRaw, 0, 0xC8, 1) // kind, cardinality=0, size=200 (uvarint)
+	buf = append(buf, make([]byte, 200)...) // oversized raw payload
+	if _, err := UnmarshalCompressed(buf); err == nil {
+		t.Fatalf("expect error for oversized raw chunk payload\n")
+	}
+
+	// A sparse chunk with an odd payload length.
+	buf = nil
+	buf = append(buf, 8, 1)
+	buf = append(buf, chunkSparseSet, 0, 1)
+	buf = append(buf, 0)
+	if _, err := UnmarshalCompressed(buf); err == nil {
+		t.Fatalf("expect error for odd sparse chunk payload length\n")
+	}
+}
@@ -0,0 +1,70 @@
+package bitarray
+
+import (
+	"encoding/binary"
+	mathbits "math/bits"
+)
+
+// NextSet returns the index of the first set bit at or after index from,
+// and whether one was found.
+func (bits *BitArray) NextSet(from int) (int, bool) {
+	return bits.nextBit(from, false)
+}
+
+// NextClear returns the index of the first clear bit at or after index
+// from, and whether one was found.
+func (bits *BitArray) NextClear(from int) (int, bool) {
+	return bits.nextBit(from, true)
+}
+
+// nextBit skips whole words that are all zero (or, when clear is true, all
+// one) using bit-tricks: isolate the lowest set bit of the masked word via
+// TrailingZeros64 instead of scanning bit by bit like ToArray does.
+func (bits *BitArray) nextBit(from int, clear bool) (int, bool) {
+	if from < 0 {
+		from = 0
+	}
+	if from >= bits.length {
+		return 0, false
+	}
+
+	wordStart := (from / 8 / _BytesPW) * _BytesPW
+	offset := uint(from - wordStart*8)
+
+	for wordStart < bits.lenpad {
+		w := binary.LittleEndian.Uint64(bits.bytes[wordStart : wordStart+_BytesPW])
+		if clear {
+			w = ^w
+		}
+		w &^= (uint64(1) << offset) - 1 // clear bits before offset
+
+		if w != 0 {
+			idx := wordStart*8 + mathbits.TrailingZeros64(w)
+			if idx >= bits.length {
+				return 0, false
+			}
+			return idx, true
+		}
+
+		wordStart += _BytesPW
+		offset = 0
+	}
+
+	return 0, false
+}
+
+// ForEachSet calls fn with the index of every set bit in ascending order,
+// stopping early if fn returns false.
+func (bits *BitArray) ForEachSet(fn func(i int) bool) {
+	i := 0
+	for {
+		idx, ok := bits.NextSet(i)
+		if !ok {
+			return
+		}
+		if !fn(idx) {
+			return
+		}
+		i = idx + 1
+	}
+}
@@ -0,0 +1,74 @@
+package bitarray
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestNextSetNextClear(t *testing.T) {
+	fmt.Println("Test: NextSet/NextClear")
+
+	n := 200
+	bits := New(n)
+	set := []int{0, 1, 63, 64, 65, 127, 128, 199}
+	for _, i := range set {
+		bits.Put(i, 1)
+	}
+
+	got := []int{}
+	i := 0
+	for {
+		idx, ok := bits.NextSet(i)
+		if !ok {
+			break
+		}
+		got = append(got, idx)
+		i = idx + 1
+	}
+	if !reflect.DeepEqual(got, set) {
+		t.Fatalf("expect set bits %v, got %v\n", set, got)
+	}
+
+	if idx, ok := bits.NextClear(0); !ok || idx != 2 {
+		t.Fatalf("expect first clear bit 2, got %d, %v\n", idx, ok)
+	}
+	if _, ok := bits.NextSet(200); ok {
+		t.Fatalf("expect no set bit at or after length\n")
+	}
+
+	full := New(64)
+	full.Set(0, 63)
+	if _, ok := full.NextClear(0); ok {
+		t.Fatalf("expect no clear bit in a fully set BitArray\n")
+	}
+}
+
+func TestForEachSet(t *testing.T) {
+	fmt.Println("Test: ForEachSet")
+
+	n := 200
+	bits := New(n)
+	set := []int{3, 64, 130, 199}
+	for _, i := range set {
+		bits.Put(i, 1)
+	}
+
+	got := []int{}
+	bits.ForEachSet(func(i int) bool {
+		got = append(got, i)
+		return true
+	})
+	if !reflect.DeepEqual(got, set) {
+		t.Fatalf("expect set bits %v, got %v\n", set, got)
+	}
+
+	got = nil
+	bits.ForEachSet(func(i int) bool {
+		got = append(got, i)
+		return len(got) < 2
+	})
+	if !reflect.DeepEqual(got, set[:2]) {
+		t.Fatalf("expect early stop after %v, got %v\n", set[:2], got)
+	}
+}
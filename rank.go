@@ -0,0 +1,128 @@
+package bitarray
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	mathbits "math/bits"
+)
+
+// rankBlockWords is the number of 64-bit words (S) covered by one ranks
+// entry, i.e. one 512-bit superblock.
+const rankBlockWords = 8
+
+// BuildIndex precomputes a superblock index used by Rank1 and Select1 to
+// answer queries in sub-linear time: the cumulative popcount of every
+// rankBlockWords words is cached in bits.ranks. Any call to Put, Set,
+// Clear, or Not invalidates the index by nilling bits.ranks, so callers
+// doing mixed mutation and query workloads should call BuildIndex again
+// after a batch of writes.
+func (bits *BitArray) BuildIndex() {
+	nw := bits.lenpad / _BytesPW
+	nblocks := (nw + rankBlockWords - 1) / rankBlockWords
+	ranks := make([]uint32, nblocks)
+
+	cum := uint32(0)
+	for b := 0; b < nblocks; b++ {
+		start := b * rankBlockWords
+		end := start + rankBlockWords
+		if end > nw {
+			end = nw
+		}
+		for w := start; w < end; w++ {
+			word := bytes2word(bits.bytes[w*_BytesPW : (w+1)*_BytesPW])
+			cum += uint32(countbits64(word))
+		}
+		ranks[b] = cum
+	}
+
+	bits.ranks = ranks
+}
+
+// Rank1 returns the count of set bits in [0, i]. A negative i returns 0,
+// and an i at or past Len() is clamped to Len()-1. If BuildIndex was called
+// and the index is still valid, the block containing i is located in O(1)
+// via bits.ranks.
+func (bits *BitArray) Rank1(i int) int {
+	if bits.length == 0 || i < 0 {
+		return 0
+	}
+	if i >= bits.length {
+		i = bits.length - 1
+	}
+
+	wordIdx := i / BitsPW
+	offset := uint(i - wordIdx*BitsPW)
+
+	rank := 0
+	wordStart := 0
+	if bits.ranks != nil {
+		block := wordIdx / rankBlockWords
+		if block > 0 {
+			rank = int(bits.ranks[block-1])
+		}
+		wordStart = block * rankBlockWords
+	}
+
+	for w := wordStart; w < wordIdx; w++ {
+		word := bytes2word(bits.bytes[w*_BytesPW : (w+1)*_BytesPW])
+		rank += countbits64(word)
+	}
+
+	word := binary.LittleEndian.Uint64(bits.bytes[wordIdx*_BytesPW : (wordIdx+1)*_BytesPW])
+	rank += countbits64(word & (uint64(1)<<(offset+1) - 1))
+
+	return rank
+}
+
+// Select1 returns the index of the k-th set bit (0-indexed). If BuildIndex
+// was called, bits.ranks is binary-searched to find the block whose
+// cumulative count first exceeds k, then words are scanned forward from
+// there; otherwise the scan starts from the first word. Within the target
+// word, the exact position is found via a broadword select: the lowest set
+// bit is cleared repeatedly until the wanted one remains, then located with
+// TrailingZeros64.
+func (bits *BitArray) Select1(k int) (int, error) {
+	if bits.length == 0 || k < 0 {
+		msg := fmt.Sprintf("select1 index %d out of range", k)
+		return 0, errors.New(msg)
+	}
+
+	nw := bits.lenpad / _BytesPW
+	wordStart := 0
+	remaining := k + 1 // the (k+1)-th set bit overall, 1-indexed
+
+	if bits.ranks != nil {
+		lo, hi, block := 0, len(bits.ranks)-1, len(bits.ranks)
+		for lo <= hi {
+			mid := (lo + hi) / 2
+			if int(bits.ranks[mid]) > k {
+				block = mid
+				hi = mid - 1
+			} else {
+				lo = mid + 1
+			}
+		}
+		prevRank := 0
+		if block > 0 {
+			prevRank = int(bits.ranks[block-1])
+		}
+		remaining = k + 1 - prevRank
+		wordStart = block * rankBlockWords
+	}
+
+	for w := wordStart; w < nw; w++ {
+		word := binary.LittleEndian.Uint64(bits.bytes[w*_BytesPW : (w+1)*_BytesPW])
+		c := countbits64(word)
+		if c >= remaining {
+			for j := 1; j < remaining; j++ {
+				word &= word - 1 // clear the lowest set bit
+			}
+			return w*BitsPW + mathbits.TrailingZeros64(word), nil
+		}
+		remaining -= c
+	}
+
+	msg := fmt.Sprintf("select1 index %d out of range [0, %d)", k, bits.Count())
+	return 0, errors.New(msg)
+}
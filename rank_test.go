@@ -0,0 +1,138 @@
+package bitarray
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRank1(t *testing.T) {
+	fmt.Println("Test: Rank1")
+
+	n := 6401
+	bits := New(n)
+	set := []int{0, 1, 63, 64, 65, 511, 512, 513, 4095, 4096, 6400}
+	for _, i := range set {
+		bits.Put(i, 1)
+	}
+
+	want := 0
+	j := 0
+	for i := 0; i < n; i++ {
+		if j < len(set) && set[j] == i {
+			want++
+			j++
+		}
+		if r := bits.Rank1(i); r != want {
+			t.Fatalf("Rank1(%d): expect %d, got %d\n", i, want, r)
+		}
+	}
+}
+
+func TestRank1WithIndex(t *testing.T) {
+	fmt.Println("Test: Rank1 with BuildIndex")
+
+	n := 6401
+	bits := New(n)
+	for i := 0; i < n; i += 3 {
+		bits.Put(i, 1)
+	}
+	bits.BuildIndex()
+
+	for _, i := range []int{0, 2, 3, 511, 512, 4096, 6400} {
+		withIndex := bits.Rank1(i)
+
+		plain := New(n)
+		for j := 0; j < n; j += 3 {
+			plain.Put(j, 1)
+		}
+		want := plain.Rank1(i)
+
+		if withIndex != want {
+			t.Fatalf("Rank1(%d) with index: expect %d, got %d\n", i, want, withIndex)
+		}
+	}
+}
+
+func TestRank1InvalidatedByMutation(t *testing.T) {
+	fmt.Println("Test: Rank1 index invalidated by Put")
+
+	bits := New(128)
+	bits.Set(0, 63)
+	bits.BuildIndex()
+
+	bits.Put(100, 1)
+	if bits.ranks != nil {
+		t.Fatalf("expect ranks to be invalidated after Put\n")
+	}
+
+	if r := bits.Rank1(127); r != 65 {
+		t.Fatalf("Rank1(127): expect 65, got %d\n", r)
+	}
+}
+
+func TestRank1InvalidatedByInPlaceBitwiseOp(t *testing.T) {
+	fmt.Println("Test: Rank1 index invalidated by OrInPlace")
+
+	n := 1026
+	a := New(n)
+	a.BuildIndex()
+
+	b := New(n)
+	b.Set(0, 511)
+
+	if err := a.OrInPlace(b); err != nil {
+		t.Fatalf("OrInPlace failed: %v\n", err)
+	}
+	if a.ranks != nil {
+		t.Fatalf("expect ranks to be invalidated after OrInPlace\n")
+	}
+
+	if r := a.Rank1(600); r != 512 {
+		t.Fatalf("Rank1(600): expect 512, got %d\n", r)
+	}
+}
+
+func TestRank1Select1OnEmptyBitArray(t *testing.T) {
+	fmt.Println("Test: Rank1/Select1 on a zero-length BitArray")
+
+	bits := New(0)
+
+	if r := bits.Rank1(0); r != 0 {
+		t.Fatalf("Rank1(0): expect 0, got %d\n", r)
+	}
+
+	if _, err := bits.Select1(0); err == nil {
+		t.Fatalf("expect error for Select1 on an empty BitArray\n")
+	}
+}
+
+func TestSelect1(t *testing.T) {
+	fmt.Println("Test: Select1")
+
+	n := 6401
+	bits := New(n)
+	set := []int{0, 1, 63, 64, 65, 511, 512, 513, 4095, 4096, 6400}
+	for _, i := range set {
+		bits.Put(i, 1)
+	}
+
+	for k, want := range set {
+		if got, err := bits.Select1(k); err != nil || got != want {
+			t.Fatalf("Select1(%d): expect (%d, nil), got (%d, %v)\n", k, want, got, err)
+		}
+	}
+
+	if _, err := bits.Select1(len(set)); err == nil {
+		t.Fatalf("expect error for Select1 past the last set bit\n")
+	}
+	if _, err := bits.Select1(-1); err == nil {
+		t.Fatalf("expect error for Select1 with negative index\n")
+	}
+
+	bits.BuildIndex()
+	for k, want := range set {
+		if got, err := bits.Select1(k); err != nil || got != want {
+			t.Fatalf("Select1(%d) with index: expect (%d, nil), got (%d, %v)\n", k, want, got, err)
+		}
+	}
+}
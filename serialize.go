@@ -0,0 +1,130 @@
+package bitarray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// singleByteReader adapts an io.Reader into an io.ByteReader one byte at a
+// time, without the read-ahead buffering of bufio.Reader, so ReadFrom never
+// consumes more of r than its own wire format needs.
+type singleByteReader struct {
+	r   io.Reader
+	n   int64
+	buf [1]byte
+}
+
+func (sr *singleByteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(sr.r, sr.buf[:]); err != nil {
+		return 0, err
+	}
+	sr.n++
+	return sr.buf[0], nil
+}
+
+// WriteTo encodes the BitArray and writes it to w, implementing io.WriterTo.
+// The wire format is: a varint bit length, a varint count of the 64-bit
+// words written (every word up to the last non-zero one; trailing zero
+// words are trimmed), followed by those words in big-endian order. It returns the number of bytes written.
+func (bits *BitArray) WriteTo(w io.Writer) (int64, error) {
+	nw := bits.lenpad / _BytesPW
+	for nw > 0 {
+		word := bytes2word(bits.bytes[(nw-1)*_BytesPW : nw*_BytesPW])
+		if word != 0 {
+			break
+		}
+		nw--
+	}
+
+	header := make([]byte, binary.MaxVarintLen64*2)
+	n := binary.PutUvarint(header, uint64(bits.length))
+	n += binary.PutUvarint(header[n:], uint64(nw))
+
+	written, err := w.Write(header[:n])
+	total := int64(written)
+	if err != nil {
+		return total, err
+	}
+
+	for i := 0; i < nw*_BytesPW; i += _BytesPW {
+		word := bytes2word(bits.bytes[i : i+_BytesPW])
+		if err := binary.Write(w, binary.BigEndian, word); err != nil {
+			return total, err
+		}
+		total += int64(_BytesPW)
+	}
+
+	return total, nil
+}
+
+// ReadFrom decodes a BitArray written by WriteTo from r, replacing the
+// receiver's contents, and implements io.ReaderFrom. It returns the number
+// of bytes read.
+func (bits *BitArray) ReadFrom(r io.Reader) (int64, error) {
+	sr := &singleByteReader{r: r}
+
+	length, err := binary.ReadUvarint(sr)
+	if err != nil {
+		return sr.n, err
+	}
+	nw, err := binary.ReadUvarint(sr)
+	if err != nil {
+		return sr.n, err
+	}
+
+	nbits := New(int(length))
+	if int(nw) > nwords(nbits.length) {
+		msg := fmt.Sprintf("stored word count %d exceeds nwords(%d)", nw, nbits.length)
+		return sr.n, errors.New(msg)
+	}
+
+	total := sr.n
+	read, err := io.ReadFull(r, nbits.bytes[:int(nw)*_BytesPW])
+	total += int64(read)
+	if err != nil {
+		return total, err
+	}
+
+	*bits = *nbits
+	return total, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the same wire
+// format as WriteTo.
+func (bits *BitArray) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := bits.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using the same wire
+// format as ReadFrom.
+func (bits *BitArray) UnmarshalBinary(data []byte) error {
+	_, err := bits.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// MarshalJSON implements json.Marshaler by encoding the BitArray's binary
+// representation as a JSON string.
+func (bits *BitArray) MarshalJSON() ([]byte, error) {
+	data, err := bits.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the inverse of MarshalJSON.
+func (bits *BitArray) UnmarshalJSON(data []byte) error {
+	var raw []byte
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return bits.UnmarshalBinary(raw)
+}
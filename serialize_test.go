@@ -0,0 +1,119 @@
+package bitarray
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestWriteToReadFrom(t *testing.T) {
+	fmt.Println("Test: WriteTo/ReadFrom")
+
+	for _, n := range []int{0, 1, 63, 64, 65, 6401} {
+		bits := New(n)
+		for i := 0; i < n; i += 7 {
+			bits.Put(i, 1)
+		}
+
+		var buf bytes.Buffer
+		if _, err := bits.WriteTo(&buf); err != nil {
+			t.Fatalf("WriteTo failed for length %d: %v\n", n, err)
+		}
+
+		got := new(BitArray)
+		if _, err := got.ReadFrom(&buf); err != nil {
+			t.Fatalf("ReadFrom failed for length %d: %v\n", n, err)
+		}
+
+		if !bits.Eq(got) {
+			t.Fatalf("expect round-trip equal for length %d, got %v != %v\n", n, bits.ToArray(), got.ToArray())
+		}
+	}
+}
+
+func TestReadFromDoesNotConsumeTrailingData(t *testing.T) {
+	fmt.Println("Test: ReadFrom leaves trailing stream data untouched")
+
+	bits := New(65)
+	bits.Set(0, 64)
+
+	var buf bytes.Buffer
+	if _, err := bits.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v\n", err)
+	}
+
+	sentinel := "sentinel"
+	buf.WriteString(sentinel)
+
+	got := new(BitArray)
+	n, err := got.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("ReadFrom failed: %v\n", err)
+	}
+	if !bits.Eq(got) {
+		t.Fatalf("expect round-trip equal, got %v != %v\n", bits.ToArray(), got.ToArray())
+	}
+
+	if rest := buf.String(); rest != sentinel {
+		t.Fatalf("expect trailing data %q untouched, got %q (ReadFrom reported n=%d)\n", sentinel, rest, n)
+	}
+}
+
+func TestReadFromRejectsOversizedWordCount(t *testing.T) {
+	fmt.Println("Test: ReadFrom rejects oversized word count")
+
+	var buf bytes.Buffer
+	buf.Write([]byte{64, 2}) // length=64 (1 word), word count=2
+	buf.Write(make([]byte, 16))
+
+	bits := new(BitArray)
+	if _, err := bits.ReadFrom(&buf); err == nil {
+		t.Fatalf("expect error for stored word count exceeding nwords(length)\n")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	fmt.Println("Test: MarshalBinary/UnmarshalBinary")
+
+	bits := New(6401)
+	for i := 0; i < bits.Len(); i += 3 {
+		bits.Put(i, 1)
+	}
+
+	data, err := bits.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v\n", err)
+	}
+
+	got := new(BitArray)
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v\n", err)
+	}
+
+	if !bits.Eq(got) {
+		t.Fatalf("expect round-trip equal, got %v != %v\n", bits.ToArray(), got.ToArray())
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	fmt.Println("Test: MarshalJSON/UnmarshalJSON")
+
+	bits := New(128)
+	bits.Set(10, 20)
+	bits.Put(127, 1)
+
+	data, err := json.Marshal(bits)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v\n", err)
+	}
+
+	got := new(BitArray)
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v\n", err)
+	}
+
+	if !bits.Eq(got) {
+		t.Fatalf("expect round-trip equal, got %v != %v\n", bits.ToArray(), got.ToArray())
+	}
+}
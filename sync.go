@@ -0,0 +1,163 @@
+package bitarray
+
+import (
+	"io"
+	"sync"
+)
+
+// SyncBitArray wraps a BitArray with a mutex, making all of its operations
+// safe for concurrent use by multiple goroutines.
+type SyncBitArray struct {
+	mu   sync.Mutex
+	bits *BitArray
+}
+
+// NewSync creates a new SyncBitArray with length(bits).
+func NewSync(length int) *SyncBitArray {
+	return &SyncBitArray{bits: New(length)}
+}
+
+// Len return the length of the BitArray.
+func (s *SyncBitArray) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.Len()
+}
+
+// Count return the count of bit 1.
+func (s *SyncBitArray) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.Count()
+}
+
+// Get return the bit by index n.
+// If index out of range [0, SyncBitArray.Len()), return error.
+func (s *SyncBitArray) Get(n int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.Get(n)
+}
+
+// Put set the nth bit with 0/1, and return the old value of nth bit.
+// If index out of range [0, SyncBitArray.Len()), return error.
+func (s *SyncBitArray) Put(n int, bit int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.Put(n, bit)
+}
+
+// Set the value of all bits to 1, which index range between low and high,
+// both low and high included.
+func (s *SyncBitArray) Set(low int, high int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.Set(low, high)
+}
+
+// Clear set the value of all bits to 0, which index range between low and high,
+// both low and high included.
+func (s *SyncBitArray) Clear(low int, high int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.Clear(low, high)
+}
+
+// Not flips the value of all bits, which index range between low and high,
+// both low and high included.
+func (s *SyncBitArray) Not(low int, high int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.Not(low, high)
+}
+
+// Eq check whether the BitArray is equal to another SyncBitArray.
+func (s *SyncBitArray) Eq(o *SyncBitArray) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return s.bits.Eq(o.bits)
+}
+
+// Leq check whether the BitArray is the subset of the another SyncBitArray.
+func (s *SyncBitArray) Leq(o *SyncBitArray) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return s.bits.Leq(o.bits)
+}
+
+// Lt check whether the BitArray is the proper subset of the another SyncBitArray.
+func (s *SyncBitArray) Lt(o *SyncBitArray) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return s.bits.Lt(o.bits)
+}
+
+// ToArray converts the BitArray to a array of integers, and return.
+func (s *SyncBitArray) ToArray() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.ToArray()
+}
+
+// WriteTo encodes and writes the underlying BitArray, see BitArray.WriteTo.
+func (s *SyncBitArray) WriteTo(w io.Writer) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.WriteTo(w)
+}
+
+// ReadFrom decodes a BitArray into the underlying BitArray, see BitArray.ReadFrom.
+func (s *SyncBitArray) ReadFrom(r io.Reader) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.ReadFrom(r)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, see BitArray.MarshalBinary.
+func (s *SyncBitArray) MarshalBinary() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, see BitArray.UnmarshalBinary.
+func (s *SyncBitArray) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.UnmarshalBinary(data)
+}
+
+// MarshalJSON implements json.Marshaler, see BitArray.MarshalJSON.
+func (s *SyncBitArray) MarshalJSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, see BitArray.UnmarshalJSON.
+func (s *SyncBitArray) UnmarshalJSON(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bits.UnmarshalJSON(data)
+}
+
+// RangeLocked runs fn with the mutex held, passing the underlying BitArray
+// so callers can perform multi-op transactions (e.g. Set then Count)
+// atomically without exposing the internal mutex.
+func (s *SyncBitArray) RangeLocked(fn func(*BitArray) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.bits)
+}
+
+// AtomicPut sets the nth bit with 0/1 and returns its old value, exactly as
+// Put does: it takes the same mutex and does not use sync/atomic.
+func (s *SyncBitArray) AtomicPut(n int, bit int) (int, error) {
+	return s.Put(n, bit)
+}
@@ -0,0 +1,128 @@
+package bitarray
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSyncBasic(t *testing.T) {
+	fmt.Println("Test: SyncBitArray Get/Put")
+
+	bits := NewSync(65)
+
+	for i := 0; i < bits.Len(); i++ {
+		if bit, _ := bits.Get(i); bit != 0 {
+			t.Fatalf("expect bit 0")
+		}
+		bits.Put(i, 1)
+		if bit, _ := bits.Get(i); bit != 1 {
+			t.Fatalf("expect bit 1, got: %d\n", bit)
+		}
+	}
+}
+
+func TestSyncConcurrentPut(t *testing.T) {
+	fmt.Println("Test: SyncBitArray concurrent Put")
+
+	n := 1024
+	bits := NewSync(n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bits.Put(i, 1)
+		}(i)
+	}
+	wg.Wait()
+
+	if c := bits.Count(); c != n {
+		t.Fatalf("expect count of bit 1: %d, got: %d\n", n, c)
+	}
+}
+
+func TestAtomicPut(t *testing.T) {
+	fmt.Println("Test: SyncBitArray AtomicPut")
+
+	n := 1024
+	bits := NewSync(n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := bits.AtomicPut(i, 1); err != nil {
+				t.Errorf("AtomicPut(%d, 1) failed: %v\n", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if c := bits.Count(); c != n {
+		t.Fatalf("expect count of bit 1: %d, got: %d\n", n, c)
+	}
+
+	old, err := bits.AtomicPut(5, 0)
+	if err != nil {
+		t.Fatalf("AtomicPut failed: %v\n", err)
+	}
+	if old != 1 {
+		t.Fatalf("expect old bit 1, got: %d\n", old)
+	}
+	if bit, _ := bits.Get(5); bit != 0 {
+		t.Fatalf("expect bit 0, got: %d\n", bit)
+	}
+
+	if _, err := bits.AtomicPut(-1, 1); err == nil {
+		t.Fatalf("expect AtomicPut index -1 out of range\n")
+	}
+}
+
+func TestAtomicPutMixedWithPut(t *testing.T) {
+	fmt.Println("Test: SyncBitArray AtomicPut mixed with Put on the same word")
+
+	n := 128 // spans two 64-bit words
+	bits := NewSync(n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				bits.Put(i, 1)
+			} else {
+				if _, err := bits.AtomicPut(i, 1); err != nil {
+					t.Errorf("AtomicPut(%d, 1) failed: %v\n", i, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if c := bits.Count(); c != n {
+		t.Fatalf("expect count of bit 1: %d, got: %d\n", n, c)
+	}
+}
+
+func TestRangeLocked(t *testing.T) {
+	fmt.Println("Test: SyncBitArray RangeLocked")
+
+	bits := NewSync(64)
+
+	err := bits.RangeLocked(func(b *BitArray) error {
+		if err := b.Set(0, 9); err != nil {
+			return err
+		}
+		if c := b.Count(); c != 10 {
+			t.Fatalf("expect count of bit 1: %d, got: %d\n", 10, c)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RangeLocked failed: %v\n", err)
+	}
+}